@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"reflect"
 	"strconv"
+	"sync"
 	"time"
 
 	log "github.com/sirupsen/logrus"
@@ -14,38 +15,125 @@ import (
 	"github.com/twistedgrim/go-ecobee/ecobee"
 )
 
-type descs string
+// defaultRefreshInterval is the interval at which the collector polls the
+// Ecobee API in the background when no WithRefreshInterval option is given.
+// Ecobee rate limits polling to roughly once every 3 minutes per thermostat.
+const defaultRefreshInterval = 3 * time.Minute
+
+// equipmentStatusFields enumerates the ecobee.ThermostatSummary equipment
+// status bools hvac_in_operation reports on, read via reflection since the
+// struct has no other way to enumerate "which equipment ran". This must stay
+// an explicit allowlist rather than "every bool field" so a future
+// non-equipment bool added to ThermostatSummary doesn't silently show up as
+// a bogus piece of equipment.
+var equipmentStatusFields = []string{
+	"HeatPump", "HeatPump2", "HeatPump3",
+	"CompCool1", "CompCool2",
+	"AuxHeat1", "AuxHeat2", "AuxHeat3",
+	"Fan", "Humidifier", "Dehumidifier", "Ventilator", "Economizer",
+	"CompHotWater", "AuxHotWater",
+}
+
+type descs struct {
+	prefix      string
+	constLabels prometheus.Labels
+}
 
 func (d descs) new(fqName, help string, variableLabels []string) *prometheus.Desc {
-	return prometheus.NewDesc(fmt.Sprintf("%s_%s", d, fqName), help, variableLabels, nil)
+	return prometheus.NewDesc(fmt.Sprintf("%s_%s", d.prefix, fqName), help, variableLabels, d.constLabels)
 }
 
 // eCollector implements prometheus.eCollector to gather ecobee metrics on-demand.
 type eCollector struct {
 	client *ecobee.Client
 
+	// account, if non-empty, is attached as a const "account" label to every
+	// metric; set when the collector is owned by a Registry. Empty for a
+	// collector created directly via NewEcobeeCollector.
+	account string
+
+	// config controls which optional metric groups are exported.
+	config Config
+
+	// refreshInterval controls how often the background refresh loop polls
+	// the Ecobee API; Collect itself never calls the API directly.
+	refreshInterval time.Duration
+
+	// stopc, once closed by Stop, terminates the background refresh loop.
+	stopc chan struct{}
+
+	// cacheMu guards the cached API responses served by Collect.
+	cacheMu           sync.RWMutex
+	cachedThermostats []ecobee.Thermostat
+	cachedSummary     map[string]ecobee.ThermostatSummary
+	lastFetchElapsed  time.Duration
+
 	// per-query descriptors
 	fetchTime *prometheus.Desc
 
+	// self-telemetry metrics, kept as persistent collectors rather than
+	// ConstMetric descriptors so their values survive across scrapes
+	up                  prometheus.Gauge
+	lastRefreshTime     prometheus.Gauge
+	lastRefreshDuration prometheus.Gauge
+	cacheUpdatedTime    prometheus.Gauge
+	refreshIntervalSecs prometheus.Gauge
+	apiRequestsTotal    *prometheus.CounterVec
+	apiErrorsTotal      *prometheus.CounterVec
+
 	// runtime descriptors
 	actualTemperature, targetTemperatureMin, targetTemperatureMax, currentHvacMode, holdTempMetric, hvacInOperation *prometheus.Desc
 
+	// extra runtime/weather descriptors, gated by Config
+	desiredHumidity, desiredDehumidity, desiredFanMode *prometheus.Desc
+	outdoorTemperature, outdoorHumidity                *prometheus.Desc
+
 	// sensor descriptors
 	temperature, humidity, occupancy, inUse *prometheus.Desc
 }
 
+// Option configures optional behavior of an eCollector.
+type Option func(*eCollector)
+
+// WithRefreshInterval overrides the default interval at which the collector's
+// background goroutine polls the Ecobee API. Pick a value that respects
+// Ecobee's rate limit (roughly one poll per thermostat every 3 minutes).
+func WithRefreshInterval(d time.Duration) Option {
+	return func(c *eCollector) {
+		c.refreshInterval = d
+	}
+}
+
 // NewEcobeeCollector returns a new eCollector with the given prefix assigned to all
 // metrics. Note that Prometheus metrics must be unique! Don't try to create
-// two Collectors with the same metric prefix.
-func NewEcobeeCollector(c *ecobee.Client, metricPrefix string) *eCollector {
-	d := descs(metricPrefix)
+// two Collectors with the same metric prefix. A background goroutine refreshes
+// the collector's cache at refreshInterval (default defaultRefreshInterval,
+// override with WithRefreshInterval); Collect never calls the Ecobee API itself.
+// For scraping multiple Ecobee accounts from one exporter, see Registry instead.
+func NewEcobeeCollector(c *ecobee.Client, metricPrefix string, opts ...Option) *eCollector {
+	return newCollector(c, metricPrefix, "", opts...)
+}
+
+// newCollector is the shared constructor behind NewEcobeeCollector and
+// Registry: account, if non-empty, is attached as a const "account" label to
+// every metric the collector exports.
+func newCollector(c *ecobee.Client, metricPrefix, account string, opts ...Option) *eCollector {
+	var constLabels prometheus.Labels
+	if account != "" {
+		constLabels = prometheus.Labels{"account": account}
+	}
+	d := descs{prefix: metricPrefix, constLabels: constLabels}
 
 	// fields common across multiple metrics
 	runtime := []string{"thermostat_id", "thermostat_name"}
 	sensor := append(runtime, "sensor_id", "sensor_name", "sensor_type")
 
-	return &eCollector{
-		client: c,
+	ec := &eCollector{
+		client:          c,
+		account:         account,
+		config:          DefaultConfig(),
+		refreshInterval: defaultRefreshInterval,
+		stopc:           make(chan struct{}),
 
 		// collector metrics
 		fetchTime: d.new(
@@ -54,6 +142,43 @@ func NewEcobeeCollector(c *ecobee.Client, metricPrefix string) *eCollector {
 			nil,
 		),
 
+		// self-telemetry metrics
+		up: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name:        fmt.Sprintf("%s_up", metricPrefix),
+			Help:        "Whether the last scrape of the Ecobee API succeeded (1) or failed (0)",
+			ConstLabels: constLabels,
+		}),
+		lastRefreshTime: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name:        fmt.Sprintf("%s_last_refresh_time", metricPrefix),
+			Help:        "Unix timestamp of the last scrape of the Ecobee API",
+			ConstLabels: constLabels,
+		}),
+		lastRefreshDuration: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name:        fmt.Sprintf("%s_last_refresh_duration_seconds", metricPrefix),
+			Help:        "Duration in seconds of the last scrape of the Ecobee API",
+			ConstLabels: constLabels,
+		}),
+		cacheUpdatedTime: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name:        fmt.Sprintf("%s_cache_updated_time", metricPrefix),
+			Help:        "Unix timestamp at which the collector's cache was last populated from the Ecobee API",
+			ConstLabels: constLabels,
+		}),
+		refreshIntervalSecs: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name:        fmt.Sprintf("%s_refresh_interval_seconds", metricPrefix),
+			Help:        "Configured interval in seconds between background refreshes of the Ecobee API cache",
+			ConstLabels: constLabels,
+		}),
+		apiRequestsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name:        fmt.Sprintf("%s_api_requests_total", metricPrefix),
+			Help:        "Total number of requests made to the Ecobee API by endpoint and status",
+			ConstLabels: constLabels,
+		}, []string{"endpoint", "status"}),
+		apiErrorsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name:        fmt.Sprintf("%s_api_errors_total", metricPrefix),
+			Help:        "Total number of failed requests to the Ecobee API by endpoint",
+			ConstLabels: constLabels,
+		}, []string{"endpoint"}),
+
 		// thermostat (aka runtime) metrics
 		actualTemperature: d.new(
 			"actual_temperature",
@@ -86,6 +211,35 @@ func NewEcobeeCollector(c *ecobee.Client, metricPrefix string) *eCollector {
 			[]string{"thermostat_id", "thermostat_name", "equipment"},
 		),
 
+		// extra runtime metrics (Config.EnableRuntimeDetails)
+		desiredHumidity: d.new(
+			"desired_humidity",
+			"Desired humidity level to maintain in percent",
+			runtime,
+		),
+		desiredDehumidity: d.new(
+			"desired_dehumidity",
+			"Desired dehumidification level to maintain in percent",
+			runtime,
+		),
+		desiredFanMode: d.new(
+			"desired_fan_mode",
+			"Desired fan mode of thermostat",
+			[]string{"thermostat_id", "thermostat_name", "desired_fan_mode"},
+		),
+
+		// weather metrics (Config.EnableWeather)
+		outdoorTemperature: d.new(
+			"outdoor_temperature",
+			"Outdoor temperature from the thermostat's current weather forecast",
+			runtime,
+		),
+		outdoorHumidity: d.new(
+			"outdoor_humidity",
+			"Outdoor humidity from the thermostat's current weather forecast in percent",
+			runtime,
+		),
+
 		// sensor metrics
 		temperature: d.new(
 			"temperature",
@@ -108,25 +262,146 @@ func NewEcobeeCollector(c *ecobee.Client, metricPrefix string) *eCollector {
 			sensor,
 		),
 	}
+
+	for _, opt := range opts {
+		opt(ec)
+	}
+	ec.refreshIntervalSecs.Set(ec.refreshInterval.Seconds())
+
+	// Populate the cache synchronously so the first scrape after startup
+	// doesn't race the background loop and return empty.
+	ec.refresh()
+	go ec.refreshLoop()
+
+	return ec
 }
 
-// Describe dumps all metric descriptors into ch.
+// Describe dumps all enabled metric descriptors into ch.
 func (c *eCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- c.fetchTime
-	ch <- c.actualTemperature
-	ch <- c.targetTemperatureMax
-	ch <- c.targetTemperatureMin
-	ch <- c.temperature
-	ch <- c.humidity
-	ch <- c.occupancy
-	ch <- c.inUse
-	ch <- c.currentHvacMode
-	ch <- c.holdTempMetric
-	ch <- c.hvacInOperation
-}
-
-// Collect retrieves thermostat data via the ecobee API.
-func (c *eCollector) Collect(ch chan<- prometheus.Metric) {
+	ch <- c.up.Desc()
+	ch <- c.lastRefreshTime.Desc()
+	ch <- c.lastRefreshDuration.Desc()
+	ch <- c.cacheUpdatedTime.Desc()
+	ch <- c.refreshIntervalSecs.Desc()
+	c.apiRequestsTotal.Describe(ch)
+	c.apiErrorsTotal.Describe(ch)
+
+	for name, d := range c.descsByName() {
+		if c.metricEnabled(name) {
+			ch <- d
+		}
+	}
+}
+
+// descsByName returns every filterable metric descriptor keyed by the
+// Config.DisabledMetrics name that controls it.
+func (c *eCollector) descsByName() map[string]*prometheus.Desc {
+	return map[string]*prometheus.Desc{
+		"actual_temperature":     c.actualTemperature,
+		"target_temperature_max": c.targetTemperatureMax,
+		"target_temperature_min": c.targetTemperatureMin,
+		"temperature":            c.temperature,
+		"humidity":               c.humidity,
+		"occupancy":              c.occupancy,
+		"in_use":                 c.inUse,
+		"current_hvac_mode":      c.currentHvacMode,
+		"hold_temperature":       c.holdTempMetric,
+		"hvac_in_operation":      c.hvacInOperation,
+		"desired_humidity":       c.desiredHumidity,
+		"desired_dehumidity":     c.desiredDehumidity,
+		"desired_fan_mode":       c.desiredFanMode,
+		"outdoor_temperature":    c.outdoorTemperature,
+		"outdoor_humidity":       c.outdoorHumidity,
+	}
+}
+
+// metricEnabled reports whether name is absent from Config.DisabledMetrics.
+func (c *eCollector) metricEnabled(name string) bool {
+	for _, disabled := range c.config.DisabledMetrics {
+		if disabled == name {
+			return false
+		}
+	}
+	return true
+}
+
+// thermostatName resolves the label value to use for a thermostat: blanked
+// if Config.DropThermostatName is set, else Config.ThermostatNames' override
+// if present, else the live name reported by the Ecobee API.
+func (c *eCollector) thermostatName(id, liveName string) string {
+	if c.config.DropThermostatName {
+		return ""
+	}
+	if override, ok := c.config.ThermostatNames[id]; ok {
+		return override
+	}
+	return liveName
+}
+
+// sensorName resolves the label value to use for a remote sensor, the same
+// way thermostatName does for thermostats.
+func (c *eCollector) sensorName(id, liveName string) string {
+	if c.config.DropSensorName {
+		return ""
+	}
+	if override, ok := c.config.SensorNames[id]; ok {
+		return override
+	}
+	return liveName
+}
+
+// recordRefreshResult updates the self-telemetry gauges to reflect the
+// outcome of a background refresh.
+func (c *eCollector) recordRefreshResult(start time.Time, ok bool) {
+	if ok {
+		c.up.Set(1)
+		c.cacheUpdatedTime.Set(float64(time.Now().Unix()))
+	} else {
+		c.up.Set(0)
+	}
+	c.lastRefreshTime.Set(float64(time.Now().Unix()))
+	c.lastRefreshDuration.Set(time.Now().Sub(start).Seconds())
+}
+
+// emitSelfTelemetry flushes the self-telemetry metrics to ch.
+func (c *eCollector) emitSelfTelemetry(ch chan<- prometheus.Metric) {
+	ch <- c.up
+	ch <- c.lastRefreshTime
+	ch <- c.lastRefreshDuration
+	ch <- c.cacheUpdatedTime
+	ch <- c.refreshIntervalSecs
+	c.apiRequestsTotal.Collect(ch)
+	c.apiErrorsTotal.Collect(ch)
+}
+
+// refreshLoop refreshes the collector's cache every refreshInterval,
+// respecting Ecobee's API rate limits regardless of how often Prometheus
+// scrapes Collect. The first refresh happens synchronously in newCollector,
+// before this loop starts, so the first scrape after startup isn't empty.
+func (c *eCollector) refreshLoop() {
+	ticker := time.NewTicker(c.refreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.refresh()
+		case <-c.stopc:
+			return
+		}
+	}
+}
+
+// Stop terminates the background refresh loop. A stopped collector keeps
+// serving its last cached values from Collect but never refreshes them
+// again. Stop must be called at most once: a second call panics.
+func (c *eCollector) Stop() {
+	close(c.stopc)
+}
+
+// refresh fetches thermostat data via the Ecobee API and stores it in the
+// collector's cache for Collect to serve.
+func (c *eCollector) refresh() {
 	start := time.Now()
 	tt, err := c.client.GetThermostats(ecobee.Selection{
 		SelectionType:   "registered",
@@ -134,39 +409,116 @@ func (c *eCollector) Collect(ch chan<- prometheus.Metric) {
 		IncludeRuntime:  true,
 		IncludeSettings: true,
 		IncludeEvents:   true,
+		IncludeWeather:  true,
 	})
 	elapsed := time.Now().Sub(start)
-	ch <- prometheus.MustNewConstMetric(c.fetchTime, prometheus.GaugeValue, elapsed.Seconds())
 	if err != nil {
+		c.apiRequestsTotal.WithLabelValues("thermostats", "error").Inc()
+		c.apiErrorsTotal.WithLabelValues("thermostats").Inc()
+		log.Error(err)
+		c.recordRefreshResult(start, false)
+		return
+	}
+	c.apiRequestsTotal.WithLabelValues("thermostats", "success").Inc()
+
+	statSummary, err := c.client.GetThermostatSummary(ecobee.Selection{
+		SelectionType:          "registered",
+		IncludeEquipmentStatus: true,
+	})
+	if err != nil {
+		c.apiRequestsTotal.WithLabelValues("thermostat_summary", "error").Inc()
+		c.apiErrorsTotal.WithLabelValues("thermostat_summary").Inc()
 		log.Error(err)
+		c.recordRefreshResult(start, false)
 		return
 	}
+	c.apiRequestsTotal.WithLabelValues("thermostat_summary", "success").Inc()
+
+	c.cacheMu.Lock()
+	c.cachedThermostats = tt
+	c.cachedSummary = statSummary
+	c.lastFetchElapsed = elapsed
+	c.cacheMu.Unlock()
+
+	c.recordRefreshResult(start, true)
+}
+
+// Collect serves the most recently cached thermostat data; it never calls
+// the Ecobee API directly. See refreshLoop for how the cache is populated.
+func (c *eCollector) Collect(ch chan<- prometheus.Metric) {
+	c.cacheMu.RLock()
+	tt := c.cachedThermostats
+	statSummary := c.cachedSummary
+	elapsed := c.lastFetchElapsed
+	c.cacheMu.RUnlock()
+
+	ch <- prometheus.MustNewConstMetric(c.fetchTime, prometheus.GaugeValue, elapsed.Seconds())
+
 	for _, t := range tt {
-		tFields := []string{t.Identifier, t.Name}
+		thermostatName := c.thermostatName(t.Identifier, t.Name)
+		tFields := []string{t.Identifier, thermostatName}
 		if t.Runtime.Connected {
-			ch <- prometheus.MustNewConstMetric(
-				c.actualTemperature, prometheus.GaugeValue, float64(t.Runtime.ActualTemperature)/10, tFields...,
-			)
-			ch <- prometheus.MustNewConstMetric(
-				c.targetTemperatureMax, prometheus.GaugeValue, float64(t.Runtime.DesiredCool)/10, tFields...,
-			)
-			ch <- prometheus.MustNewConstMetric(
-				c.targetTemperatureMin, prometheus.GaugeValue, float64(t.Runtime.DesiredHeat)/10, tFields...,
-			)
-			ch <- prometheus.MustNewConstMetric(
-				c.currentHvacMode, prometheus.GaugeValue, 0, t.Identifier, t.Name, t.Settings.HvacMode,
-			)
-			if t.Settings.HvacMode != "off" {
+			if c.metricEnabled("actual_temperature") {
+				ch <- prometheus.MustNewConstMetric(
+					c.actualTemperature, prometheus.GaugeValue, float64(t.Runtime.ActualTemperature)/10, tFields...,
+				)
+			}
+			if c.metricEnabled("target_temperature_max") {
+				ch <- prometheus.MustNewConstMetric(
+					c.targetTemperatureMax, prometheus.GaugeValue, float64(t.Runtime.DesiredCool)/10, tFields...,
+				)
+			}
+			if c.metricEnabled("target_temperature_min") {
+				ch <- prometheus.MustNewConstMetric(
+					c.targetTemperatureMin, prometheus.GaugeValue, float64(t.Runtime.DesiredHeat)/10, tFields...,
+				)
+			}
+			if c.metricEnabled("current_hvac_mode") {
+				ch <- prometheus.MustNewConstMetric(
+					c.currentHvacMode, prometheus.GaugeValue, 0, t.Identifier, thermostatName, t.Settings.HvacMode,
+				)
+			}
+			if c.config.EnableRuntimeDetails {
+				if c.metricEnabled("desired_humidity") {
+					ch <- prometheus.MustNewConstMetric(
+						c.desiredHumidity, prometheus.GaugeValue, float64(t.Runtime.DesiredHumidity), tFields...,
+					)
+				}
+				if c.metricEnabled("desired_dehumidity") {
+					ch <- prometheus.MustNewConstMetric(
+						c.desiredDehumidity, prometheus.GaugeValue, float64(t.Runtime.DesiredDehumidity), tFields...,
+					)
+				}
+				if c.metricEnabled("desired_fan_mode") {
+					ch <- prometheus.MustNewConstMetric(
+						c.desiredFanMode, prometheus.GaugeValue, 0, t.Identifier, thermostatName, t.Runtime.DesiredFanMode,
+					)
+				}
+			}
+			if c.config.EnableWeather && len(t.Weather.Forecasts) > 0 {
+				forecast := t.Weather.Forecasts[0]
+				if c.metricEnabled("outdoor_temperature") {
+					ch <- prometheus.MustNewConstMetric(
+						c.outdoorTemperature, prometheus.GaugeValue, float64(forecast.Temperature)/10, tFields...,
+					)
+				}
+				if c.metricEnabled("outdoor_humidity") {
+					ch <- prometheus.MustNewConstMetric(
+						c.outdoorHumidity, prometheus.GaugeValue, float64(forecast.RelativeHumidity), tFields...,
+					)
+				}
+			}
+			if t.Settings.HvacMode != "off" && c.metricEnabled("hold_temperature") {
 				for _, event := range t.Events {
 					if event.Running && event.Type == "hold" {
 						if !event.IsCoolOff && t.Settings.HvacMode != "heat" {
 							ch <- prometheus.MustNewConstMetric(
-								c.holdTempMetric, prometheus.GaugeValue, float64(event.CoolHoldTemp)/10, t.Identifier, t.Name, "cool",
+								c.holdTempMetric, prometheus.GaugeValue, float64(event.CoolHoldTemp)/10, t.Identifier, thermostatName, "cool",
 							)
 						}
 						if !event.IsHeatOff && t.Settings.HvacMode != "cool" {
 							ch <- prometheus.MustNewConstMetric(
-								c.holdTempMetric, prometheus.GaugeValue, float64(event.HeatHoldTemp)/10, t.Identifier, t.Name, "heat",
+								c.holdTempMetric, prometheus.GaugeValue, float64(event.HeatHoldTemp)/10, t.Identifier, thermostatName, "heat",
 							)
 						}
 					}
@@ -174,17 +526,22 @@ func (c *eCollector) Collect(ch chan<- prometheus.Metric) {
 			}
 		}
 		for _, s := range t.RemoteSensors {
-			sFields := append(tFields, s.ID, s.Name, s.Type)
+			sFields := append(tFields, s.ID, c.sensorName(s.ID, s.Name), s.Type)
 			inUse := float64(0)
 			if s.InUse {
 				inUse = 1
 			}
-			ch <- prometheus.MustNewConstMetric(
-				c.inUse, prometheus.GaugeValue, inUse, sFields...,
-			)
+			if c.metricEnabled("in_use") {
+				ch <- prometheus.MustNewConstMetric(
+					c.inUse, prometheus.GaugeValue, inUse, sFields...,
+				)
+			}
 			for _, sc := range s.Capability {
 				switch sc.Type {
 				case "temperature":
+					if !c.metricEnabled("temperature") {
+						continue
+					}
 					if v, err := strconv.ParseFloat(sc.Value, 64); err == nil {
 						ch <- prometheus.MustNewConstMetric(
 							c.temperature, prometheus.GaugeValue, v/10, sFields...,
@@ -193,6 +550,9 @@ func (c *eCollector) Collect(ch chan<- prometheus.Metric) {
 						log.Error(err)
 					}
 				case "humidity":
+					if !c.metricEnabled("humidity") {
+						continue
+					}
 					if v, err := strconv.ParseFloat(sc.Value, 64); err == nil {
 						ch <- prometheus.MustNewConstMetric(
 							c.humidity, prometheus.GaugeValue, v, sFields...,
@@ -201,6 +561,9 @@ func (c *eCollector) Collect(ch chan<- prometheus.Metric) {
 						log.Error(err)
 					}
 				case "occupancy":
+					if !c.metricEnabled("occupancy") {
+						continue
+					}
 					switch sc.Value {
 					case "true":
 						ch <- prometheus.MustNewConstMetric(
@@ -219,33 +582,25 @@ func (c *eCollector) Collect(ch chan<- prometheus.Metric) {
 			}
 		}
 	}
-	statSummary, err := c.client.GetThermostatSummary(ecobee.Selection{
-		SelectionType:          "registered",
-		IncludeEquipmentStatus: true,
-		IncludeAlerts:          true,
-	})
-	if err != nil {
-		log.Error(err)
-		return
-	}
-	// sAttr := []string{"HeatPump", "HeatPump2", "HeatPump3", "CompCool1", "CompCool2", "AuxHeat1", "AuxHeat2", "AuxHeat3", "Fan", "Humidifier", "Dehumidifier", "Ventilator", "Economizer", "CompHotWater", "AuxHotWater"}
-	sAttr := []string{"CompCool1", "AuxHeat1", "Fan"}
-	for _, s := range statSummary {
-		if s.Connected {
-			r := reflect.ValueOf(s)
-			for _, a := range sAttr {
-				f := reflect.Indirect(r).FieldByName(a)
-				switch f.Bool() {
-				case true:
-					ch <- prometheus.MustNewConstMetric(
-						c.hvacInOperation, prometheus.GaugeValue, 1, s.Identifier, s.Name, a,
-					)
-				case false:
-					ch <- prometheus.MustNewConstMetric(
-						c.hvacInOperation, prometheus.GaugeValue, 0, s.Identifier, s.Name, a,
-					)
+	if c.config.EnableEquipmentStatus && c.metricEnabled("hvac_in_operation") {
+		for _, s := range statSummary {
+			if !s.Connected {
+				continue
+			}
+			thermostatName := c.thermostatName(s.Identifier, s.Name)
+			r := reflect.Indirect(reflect.ValueOf(s))
+			for _, a := range equipmentStatusFields {
+				f := r.FieldByName(a)
+				equipmentOn := float64(0)
+				if f.Bool() {
+					equipmentOn = 1
 				}
+				ch <- prometheus.MustNewConstMetric(
+					c.hvacInOperation, prometheus.GaugeValue, equipmentOn, s.Identifier, thermostatName, a,
+				)
 			}
 		}
 	}
+
+	c.emitSelfTelemetry(ch)
 }
@@ -0,0 +1,107 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/twistedgrim/go-ecobee/ecobee"
+)
+
+// Config controls which metrics a collector exports and how their labels are
+// rendered, inspired by ipmi_exporter's per-target config file. The zero value
+// applies no filtering but, unlike DefaultConfig, leaves every optional metric
+// group disabled; an empty MetricPrefix defaults to "ecobee".
+type Config struct {
+	// MetricPrefix is prepended to every metric name. Defaults to "ecobee".
+	MetricPrefix string `yaml:"metric_prefix" json:"metric_prefix"`
+
+	// EnableEquipmentStatus exports hvac_in_operation for the full equipment
+	// set (reflected off the Ecobee API's equipment status struct). When
+	// false, hvac_in_operation is not exported at all.
+	EnableEquipmentStatus bool `yaml:"enable_equipment_status" json:"enable_equipment_status"`
+	// EnableWeather exports outdoor temperature/humidity from the thermostat's
+	// current weather forecast.
+	EnableWeather bool `yaml:"enable_weather" json:"enable_weather"`
+	// EnableRuntimeDetails exports desired humidity/dehumidification and
+	// desired fan mode alongside the existing runtime metrics.
+	EnableRuntimeDetails bool `yaml:"enable_runtime_details" json:"enable_runtime_details"`
+
+	// DisabledMetrics drops named metrics (the suffix after the metric
+	// prefix, e.g. "humidity", "occupancy") entirely.
+	DisabledMetrics []string `yaml:"disabled_metrics" json:"disabled_metrics"`
+
+	// DropThermostatName blanks the thermostat_name label on every metric so
+	// renaming a thermostat in the Ecobee app doesn't churn series.
+	DropThermostatName bool `yaml:"drop_thermostat_name" json:"drop_thermostat_name"`
+	// DropSensorName blanks the sensor_name label on every sensor metric.
+	DropSensorName bool `yaml:"drop_sensor_name" json:"drop_sensor_name"`
+
+	// ThermostatNames maps a thermostat ID to a stable name to use in place
+	// of the live Ecobee-reported name. Ignored when DropThermostatName is set.
+	ThermostatNames map[string]string `yaml:"thermostat_names" json:"thermostat_names"`
+	// SensorNames maps a sensor ID to a stable name to use in place of the
+	// live Ecobee-reported name. Ignored when DropSensorName is set.
+	SensorNames map[string]string `yaml:"sensor_names" json:"sensor_names"`
+}
+
+// DefaultConfig returns a Config with every optional metric group enabled and
+// no label filtering, i.e. the behavior of the original NewEcobeeCollector.
+func DefaultConfig() Config {
+	return Config{
+		MetricPrefix:          "ecobee",
+		EnableEquipmentStatus: true,
+		EnableWeather:         true,
+		EnableRuntimeDetails:  true,
+	}
+}
+
+// WithConfig overrides which metric groups the collector exports and how
+// labels are rendered.
+func WithConfig(cfg Config) Option {
+	return func(c *eCollector) {
+		c.config = cfg
+	}
+}
+
+// LoadConfig reads a Config from a YAML or JSON file, selected by extension
+// (.yaml, .yml, or .json). Unset fields keep Go's zero value; callers that
+// want DefaultConfig's behavior as a base should start from it and override.
+func LoadConfig(path string) (*Config, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &Config{}
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(data, cfg); err != nil {
+			return nil, err
+		}
+	case ".json":
+		if err := json.Unmarshal(data, cfg); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("collector: unrecognized config extension %q, want .yaml, .yml, or .json", ext)
+	}
+
+	return cfg, nil
+}
+
+// NewEcobeeCollectorFromConfig returns a new eCollector configured entirely
+// by cfg. NewEcobeeCollector builds the same kind of collector through the
+// shared newCollector, using DefaultConfig with the given metric prefix and
+// no label filtering.
+func NewEcobeeCollectorFromConfig(c *ecobee.Client, cfg *Config) *eCollector {
+	prefix := cfg.MetricPrefix
+	if prefix == "" {
+		prefix = "ecobee"
+	}
+	return newCollector(c, prefix, "", WithConfig(*cfg))
+}
@@ -0,0 +1,67 @@
+package collector
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+
+	yamlPath := filepath.Join(dir, "config.yaml")
+	yamlBody := "metric_prefix: custom\nenable_weather: true\ndisabled_metrics:\n  - humidity\n"
+	if err := ioutil.WriteFile(yamlPath, []byte(yamlBody), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := LoadConfig(yamlPath)
+	if err != nil {
+		t.Fatalf("LoadConfig(%q) error: %v", yamlPath, err)
+	}
+	if cfg.MetricPrefix != "custom" || !cfg.EnableWeather || len(cfg.DisabledMetrics) != 1 || cfg.DisabledMetrics[0] != "humidity" {
+		t.Errorf("LoadConfig(yaml) = %+v, want metric_prefix=custom, enable_weather=true, disabled_metrics=[humidity]", cfg)
+	}
+
+	jsonPath := filepath.Join(dir, "config.json")
+	jsonBody := `{"metric_prefix":"custom2","drop_sensor_name":true}`
+	if err := ioutil.WriteFile(jsonPath, []byte(jsonBody), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err = LoadConfig(jsonPath)
+	if err != nil {
+		t.Fatalf("LoadConfig(%q) error: %v", jsonPath, err)
+	}
+	if cfg.MetricPrefix != "custom2" || !cfg.DropSensorName {
+		t.Errorf("LoadConfig(json) = %+v, want metric_prefix=custom2, drop_sensor_name=true", cfg)
+	}
+
+	unrecognizedPath := filepath.Join(dir, "config.txt")
+	if err := ioutil.WriteFile(unrecognizedPath, []byte("irrelevant"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadConfig(unrecognizedPath); err == nil {
+		t.Error("LoadConfig(.txt) error = nil, want error for unrecognized extension")
+	}
+
+	if _, err := LoadConfig(filepath.Join(dir, "missing.yaml")); err == nil {
+		t.Error("LoadConfig(missing file) error = nil, want error")
+	}
+}
+
+func TestMetricEnabled(t *testing.T) {
+	c := &eCollector{config: Config{DisabledMetrics: []string{"humidity", "occupancy"}}}
+
+	cases := []struct {
+		name string
+		want bool
+	}{
+		{"humidity", false},
+		{"occupancy", false},
+		{"temperature", true},
+	}
+	for _, tc := range cases {
+		if got := c.metricEnabled(tc.name); got != tc.want {
+			t.Errorf("metricEnabled(%q) = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
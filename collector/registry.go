@@ -0,0 +1,94 @@
+package collector
+
+import (
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/twistedgrim/go-ecobee/ecobee"
+)
+
+// Registry holds one eCollector per named Ecobee account so a single
+// exporter process can scrape multiple accounts. Every metric exported
+// through Registry.Collector carries an "account" label taken from the name
+// passed to Register.
+type Registry struct {
+	metricPrefix string
+	opts         []Option
+
+	mu         sync.RWMutex
+	collectors map[string]*eCollector
+}
+
+// NewRegistry returns an empty Registry. metricPrefix and opts are applied to
+// every account's collector, the same as if each had been created via
+// NewEcobeeCollector.
+func NewRegistry(metricPrefix string, opts ...Option) *Registry {
+	return &Registry{
+		metricPrefix: metricPrefix,
+		opts:         opts,
+		collectors:   make(map[string]*eCollector),
+	}
+}
+
+// Register adds or replaces the collector for the named Ecobee account.
+// Metrics for name start being refreshed in the background immediately.
+// Replacing an existing account stops its previous collector's background
+// refresh loop so it doesn't leak.
+func (r *Registry) Register(name string, c *ecobee.Client) {
+	ec := newCollector(c, r.metricPrefix, name, r.opts...)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if old, ok := r.collectors[name]; ok {
+		old.Stop()
+	}
+	r.collectors[name] = ec
+}
+
+// Collector returns a prometheus.Collector that fans Describe and Collect
+// out to every registered account. A failure isolated to one account's
+// collector never prevents the others from reporting.
+func (r *Registry) Collector() prometheus.Collector {
+	return &registryCollector{registry: r}
+}
+
+type registryCollector struct {
+	registry *Registry
+}
+
+func (rc *registryCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, c := range rc.registry.snapshot() {
+		c.Describe(ch)
+	}
+}
+
+func (rc *registryCollector) Collect(ch chan<- prometheus.Metric) {
+	for name, c := range rc.registry.snapshot() {
+		collectAccount(name, c, ch)
+	}
+}
+
+// collectAccount runs a single account's Collect in isolation: a panic in
+// one account's collector is logged and skipped rather than aborting the
+// scrape for every other account.
+func collectAccount(name string, c *eCollector, ch chan<- prometheus.Metric) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Errorf("recovered from panic collecting account %q: %v", name, r)
+		}
+	}()
+	c.Collect(ch)
+}
+
+func (r *Registry) snapshot() map[string]*eCollector {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	cs := make(map[string]*eCollector, len(r.collectors))
+	for name, c := range r.collectors {
+		cs[name] = c
+	}
+	return cs
+}